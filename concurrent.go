@@ -0,0 +1,142 @@
+package quantiles
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// concurrentShard is one shard's batch of pending observations. Once full it
+// is handed off to the background merger so Observe never blocks on the
+// master summary's lock.
+type concurrentShard struct {
+	mu      sync.Mutex
+	entries []bufEntry
+	maxSize int
+}
+
+// flushBatch is a message sent over ConcurrentStream.flush: either a full
+// shard's entries to merge, or (entries == nil) a barrier used by Snapshot
+// to wait for everything queued ahead of it to be merged first.
+type flushBatch struct {
+	entries []bufEntry
+	done    chan struct{}
+}
+
+// ConcurrentStream shards incoming observations across GOMAXPROCS per-shard
+// buffers, each guarded by its own mutex, so that Observe calls from many
+// goroutines don't serialize on a single lock the way a plain Summary would.
+// Full shards are merged into a master Summary by a single background
+// goroutine; Snapshot drains any still-pending shards and returns a clone of
+// the master, preserving the same approximation-error guarantees as the
+// serial path.
+type ConcurrentStream struct {
+	shards   []*concurrentShard
+	sizeHint int64
+	minEps   float64
+	next     uint64
+	flush    chan flushBatch
+	masterMu sync.Mutex
+	master   *Summary
+	wg       sync.WaitGroup
+}
+
+// NewConcurrentStream returns a ConcurrentStream compressing its master
+// summary to sizeHint entries at epsilon minEps, same as Summary's compress.
+func NewConcurrentStream(sizeHint int64, minEps float64) *ConcurrentStream {
+	shardCount := runtime.GOMAXPROCS(0)
+	cs := &ConcurrentStream{
+		shards:   make([]*concurrentShard, shardCount),
+		sizeHint: sizeHint,
+		minEps:   minEps,
+		flush:    make(chan flushBatch, shardCount),
+		master:   newSummary(),
+	}
+	for i := range cs.shards {
+		cs.shards[i] = &concurrentShard{maxSize: int(sizeHint)}
+	}
+
+	cs.wg.Add(1)
+	go cs.mergeLoop()
+	return cs
+}
+
+// Observe records v into one of the per-shard buffers, flushing that shard
+// to the background merger once it fills up.
+func (cs *ConcurrentStream) Observe(v float64) {
+	idx := atomic.AddUint64(&cs.next, 1) % uint64(len(cs.shards))
+	shard := cs.shards[idx]
+
+	shard.mu.Lock()
+	shard.entries = append(shard.entries, bufEntry{value: v, weight: 1})
+	var full []bufEntry
+	if len(shard.entries) >= shard.maxSize {
+		full = shard.entries
+		shard.entries = make([]bufEntry, 0, shard.maxSize)
+	}
+	shard.mu.Unlock()
+
+	if full != nil {
+		cs.flush <- flushBatch{entries: full}
+	}
+}
+
+// mergeLoop is the single background goroutine that folds flushed shard
+// buffers into the master summary. Because flush is a FIFO channel, a
+// barrier message (entries == nil) is only processed after every batch
+// queued ahead of it, which is what lets Snapshot wait for those batches.
+func (cs *ConcurrentStream) mergeLoop() {
+	defer cs.wg.Done()
+	for msg := range cs.flush {
+		if msg.entries != nil {
+			cs.mergeBatch(msg.entries)
+		}
+		if msg.done != nil {
+			close(msg.done)
+		}
+	}
+}
+
+func (cs *ConcurrentStream) mergeBatch(batch []bufEntry) {
+	sort.Slice(batch, func(i, j int) bool { return batch[i].value < batch[j].value })
+	partial := &Summary{}
+	partial.buildFromBufferEntries(batch)
+
+	cs.masterMu.Lock()
+	cs.master.Merge(partial)
+	cs.master.compress(cs.sizeHint, cs.minEps)
+	cs.masterMu.Unlock()
+}
+
+// Snapshot drains every pending shard and waits for any batches already
+// queued on flush (e.g. from a shard that just filled concurrently) to be
+// merged, then returns a clone of the master summary.
+func (cs *ConcurrentStream) Snapshot() *Summary {
+	var pending [][]bufEntry
+	for _, shard := range cs.shards {
+		shard.mu.Lock()
+		if len(shard.entries) > 0 {
+			pending = append(pending, shard.entries)
+			shard.entries = make([]bufEntry, 0, shard.maxSize)
+		}
+		shard.mu.Unlock()
+	}
+	for _, batch := range pending {
+		cs.mergeBatch(batch)
+	}
+
+	done := make(chan struct{})
+	cs.flush <- flushBatch{done: done}
+	<-done
+
+	cs.masterMu.Lock()
+	defer cs.masterMu.Unlock()
+	return cs.master.clone()
+}
+
+// Close stops the background merger. Observe must not be called after Close.
+func (cs *ConcurrentStream) Close() {
+	close(cs.flush)
+	cs.wg.Wait()
+}
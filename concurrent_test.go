@@ -0,0 +1,80 @@
+package quantiles
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestConcurrentStreamSnapshot(t *testing.T) {
+	cs := NewConcurrentStream(4, 0.01)
+	for i := 1; i <= 4; i++ {
+		cs.Observe(float64(i))
+	}
+	snap := cs.Snapshot()
+	if got, want := snap.TotalWeight(), 4.0; got != want {
+		t.Fatalf("TotalWeight() = %v, want %v", got, want)
+	}
+}
+
+func TestConcurrentStreamObserveConcurrently(t *testing.T) {
+	const goroutines = 8
+	const perGoroutine = 500
+	cs := NewConcurrentStream(32, 0.01)
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func(base int) {
+			defer wg.Done()
+			for j := 0; j < perGoroutine; j++ {
+				cs.Observe(float64(base*perGoroutine + j))
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	snap := cs.Snapshot()
+	want := float64(goroutines * perGoroutine)
+	if got := snap.TotalWeight(); got != want {
+		t.Fatalf("TotalWeight() = %v, want %v", got, want)
+	}
+	if got := snap.MinValue(); got != 0 {
+		t.Fatalf("MinValue() = %v, want 0", got)
+	}
+	if got := snap.MaxValue(); got != want-1 {
+		t.Fatalf("MaxValue() = %v, want %v", got, want-1)
+	}
+}
+
+func TestConcurrentStreamClose(t *testing.T) {
+	cs := NewConcurrentStream(8, 0.01)
+	for i := 0; i < 20; i++ {
+		cs.Observe(float64(i))
+	}
+	snap := cs.Snapshot()
+	if got, want := snap.TotalWeight(), 20.0; got != want {
+		t.Fatalf("TotalWeight() = %v, want %v", got, want)
+	}
+	cs.Close()
+}
+
+func benchmarkConcurrentStreamObserve(b *testing.B, n int) {
+	var wg sync.WaitGroup
+	wg.Add(n)
+	cs := NewConcurrentStream(1024, 0.01)
+	b.ResetTimer()
+	for i := 0; i < n; i++ {
+		go func() {
+			for j := 0; j < b.N/n; j++ {
+				cs.Observe(float64(j))
+			}
+			wg.Done()
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkConcurrentStreamObserve1(b *testing.B) { benchmarkConcurrentStreamObserve(b, 1) }
+func BenchmarkConcurrentStreamObserve2(b *testing.B) { benchmarkConcurrentStreamObserve(b, 2) }
+func BenchmarkConcurrentStreamObserve4(b *testing.B) { benchmarkConcurrentStreamObserve(b, 4) }
+func BenchmarkConcurrentStreamObserve8(b *testing.B) { benchmarkConcurrentStreamObserve(b, 8) }
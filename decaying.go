@@ -0,0 +1,148 @@
+package quantiles
+
+import (
+	"sort"
+	"time"
+)
+
+// decayingBucket holds one sub-window's worth of observations: values
+// accumulate in buffered until the next flush folds them into summary via
+// buildFromBufferEntries, mirroring how a Summary is normally built up.
+type decayingBucket struct {
+	buffered []bufEntry
+	summary  *Summary
+}
+
+func (b *decayingBucket) flush(sizeHint int64) {
+	if len(b.buffered) == 0 {
+		return
+	}
+	sort.Slice(b.buffered, func(i, j int) bool { return b.buffered[i].value < b.buffered[j].value })
+	incoming := &Summary{}
+	incoming.buildFromBufferEntries(b.buffered)
+	b.buffered = b.buffered[:0]
+	b.summary.Merge(incoming)
+	b.summary.compress(sizeHint, 0)
+}
+
+func (b *decayingBucket) reset() {
+	b.buffered = b.buffered[:0]
+	b.summary = newSummary()
+}
+
+// DecayingSummary estimates quantiles over a moving time window (e.g. the
+// last 10 minutes) instead of over the whole stream, the way Prometheus's
+// Summary metric does. It keeps ageBuckets Summaries arranged as a ring
+// buffer, each covering an equal slice of maxAge. Observe always writes into
+// the current head bucket; rotation is lazy, advancing the head and
+// clearing buckets that have aged out whenever Observe, Quantile or
+// Snapshot next runs.
+type DecayingSummary struct {
+	maxAge      time.Duration
+	bucketWidth time.Duration
+	sizeHint    int64
+	buckets     []decayingBucket
+	head        int
+	bucketEnd   time.Time
+}
+
+// NewDecayingSummary returns a DecayingSummary covering the last maxAge,
+// split into ageBuckets equal sub-windows, each compressed to about sizeHint
+// entries (compress can leave sizeHint+1).
+func NewDecayingSummary(maxAge time.Duration, ageBuckets int, sizeHint int64) *DecayingSummary {
+	if ageBuckets < 1 {
+		ageBuckets = 1
+	}
+	// A bucket width of zero (e.g. maxAge shorter than ageBuckets
+	// nanoseconds) would never advance bucketEnd, spinning rotate forever.
+	bucketWidth := maxAge / time.Duration(ageBuckets)
+	if bucketWidth <= 0 {
+		bucketWidth = time.Nanosecond
+	}
+	ds := &DecayingSummary{
+		maxAge:      maxAge,
+		bucketWidth: bucketWidth,
+		sizeHint:    sizeHint,
+		buckets:     make([]decayingBucket, ageBuckets),
+		bucketEnd:   time.Now().Add(bucketWidth),
+	}
+	for i := range ds.buckets {
+		ds.buckets[i].summary = newSummary()
+	}
+	return ds
+}
+
+// rotate advances the head bucket forward and clears any buckets it skips
+// over, so a reader never sees data older than maxAge.
+func (ds *DecayingSummary) rotate(now time.Time) {
+	for !now.Before(ds.bucketEnd) {
+		ds.head = (ds.head + 1) % len(ds.buckets)
+		ds.buckets[ds.head].reset()
+		ds.bucketEnd = ds.bucketEnd.Add(ds.bucketWidth)
+	}
+}
+
+// Observe records a new value into the current head bucket.
+func (ds *DecayingSummary) Observe(v float64) {
+	ds.rotate(time.Now())
+	head := &ds.buckets[ds.head]
+	head.buffered = append(head.buffered, bufEntry{value: v, weight: 1})
+}
+
+// liveEntries flushes every bucket and k-way merges the resulting sorted
+// entries into one slice, recomputing ranks against the combined weight.
+// Summary.Merge is deliberately not used here: repeatedly merging through it
+// would compound approximation error on every single query against a
+// long-lived DecayingSummary, since each bucket's entries would be folded in
+// via an extra merge pass on top of the ones compress already ran.
+func (ds *DecayingSummary) liveEntries() []SumEntry {
+	idx := make([]int, len(ds.buckets))
+	total := 0
+	for i := range ds.buckets {
+		ds.buckets[i].flush(ds.sizeHint)
+		total += len(ds.buckets[i].summary.entries)
+	}
+
+	merged := make([]SumEntry, 0, total)
+	for {
+		best := -1
+		for i := range ds.buckets {
+			entries := ds.buckets[i].summary.entries
+			if idx[i] >= len(entries) {
+				continue
+			}
+			if best == -1 || entries[idx[i]].value < ds.buckets[best].summary.entries[idx[best]].value {
+				best = i
+			}
+		}
+		if best == -1 {
+			break
+		}
+		e := ds.buckets[best].summary.entries[idx[best]]
+		merged = append(merged, SumEntry{value: e.value, weight: e.weight})
+		idx[best]++
+	}
+
+	cumWeight := 0.0
+	for i := range merged {
+		w := merged[i].weight
+		merged[i].minRank = cumWeight
+		merged[i].maxRank = cumWeight + w
+		cumWeight += w
+	}
+	return merged
+}
+
+// Snapshot returns a fresh, transient Summary over all live buckets,
+// suitable for a one-off batch of queries.
+func (ds *DecayingSummary) Snapshot() *Summary {
+	ds.rotate(time.Now())
+	snap := &Summary{}
+	snap.buildFromSummaryEntries(ds.liveEntries())
+	return snap
+}
+
+// Quantile returns the estimated value at quantile phi over the live window.
+func (ds *DecayingSummary) Quantile(phi float64) float64 {
+	return ds.Snapshot().Quantile(phi)
+}
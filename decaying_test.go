@@ -0,0 +1,45 @@
+package quantiles
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDecayingSummaryQuantile(t *testing.T) {
+	ds := NewDecayingSummary(time.Minute, 5, 50)
+	for i := 1; i <= 100; i++ {
+		ds.Observe(float64(i))
+	}
+
+	if got := ds.Quantile(0.5); got < 40 || got > 60 {
+		t.Fatalf("Quantile(0.5) = %v, want within [40,60]", got)
+	}
+}
+
+func TestDecayingSummarySnapshotTotalWeight(t *testing.T) {
+	ds := NewDecayingSummary(time.Minute, 5, 50)
+	for i := 0; i < 10; i++ {
+		ds.Observe(float64(i))
+	}
+	if got, want := ds.Snapshot().TotalWeight(), 10.0; got != want {
+		t.Fatalf("TotalWeight() = %v, want %v", got, want)
+	}
+}
+
+// TestDecayingSummaryDegenerateBucketWidthDoesNotHang guards against a
+// maxAge shorter than ageBuckets nanoseconds, which used to leave
+// bucketWidth at zero and spin rotate forever.
+func TestDecayingSummaryDegenerateBucketWidthDoesNotHang(t *testing.T) {
+	done := make(chan struct{})
+	go func() {
+		ds := NewDecayingSummary(time.Nanosecond, 2, 50)
+		ds.Observe(1)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Observe did not return, rotate likely spinning on a zero bucket width")
+	}
+}
@@ -0,0 +1,170 @@
+package quantiles
+
+import (
+	"math"
+	"sort"
+)
+
+// Bin is a single bucket of a Histogram: Count points averaging Mean, whose
+// raw values summed to Sum.
+type Bin struct {
+	Mean  float64
+	Count int
+	Sum   float64
+}
+
+// Histogram is a constant-memory streaming histogram sketch (Ben-Haim &
+// Tom-Tov's "A Streaming Parallel Decision Tree Algorithm"), sharing the
+// merge semantics of Summary but optimized for a fixed number of bins. Each
+// bin keeps the sum of its raw values as well as its count: Sum(b) estimates
+// how many points are <= b and Mean reports the mean of all points. There is
+// no query for the mean of only the points below a given value.
+type Histogram struct {
+	bins    []Bin
+	maxBins int
+}
+
+// NewHistogram returns an empty Histogram capped at maxBins bins. maxBins is
+// floored at 2, since mergeClosest needs at least two bins to merge.
+func NewHistogram(maxBins int) *Histogram {
+	if maxBins < 2 {
+		maxBins = 2
+	}
+	return &Histogram{
+		bins:    make([]Bin, 0, maxBins),
+		maxBins: maxBins,
+	}
+}
+
+// Insert adds x to the histogram, merging the two adjacent bins with the
+// smallest mean-gap if this pushes the bin count over maxBins.
+func (h *Histogram) Insert(x float64) {
+	for i := range h.bins {
+		if h.bins[i].Mean == x {
+			h.bins[i].Count++
+			h.bins[i].Sum += x
+			return
+		}
+	}
+
+	idx := sort.Search(len(h.bins), func(i int) bool { return h.bins[i].Mean >= x })
+	h.bins = append(h.bins, Bin{})
+	copy(h.bins[idx+1:], h.bins[idx:])
+	h.bins[idx] = Bin{Mean: x, Count: 1, Sum: x}
+
+	if len(h.bins) > h.maxBins {
+		h.mergeClosest()
+	}
+}
+
+// mergeClosest merges the two adjacent bins with the smallest mean-gap.
+func (h *Histogram) mergeClosest() {
+	minGap := math.MaxFloat64
+	minIdx := 0
+	for i := 0; i+1 < len(h.bins); i++ {
+		if gap := h.bins[i+1].Mean - h.bins[i].Mean; gap < minGap {
+			minGap = gap
+			minIdx = i
+		}
+	}
+
+	b1, b2 := h.bins[minIdx], h.bins[minIdx+1]
+	count := b1.Count + b2.Count
+	h.bins[minIdx] = Bin{
+		Mean:  (b1.Mean*float64(b1.Count) + b2.Mean*float64(b2.Count)) / float64(count),
+		Count: count,
+		Sum:   b1.Sum + b2.Sum,
+	}
+	h.bins = append(h.bins[:minIdx+1], h.bins[minIdx+2:]...)
+}
+
+// Merge folds other's bins into h, repeatedly merging the closest pair until
+// back within maxBins.
+func (h *Histogram) Merge(other *Histogram) {
+	h.bins = append(h.bins, other.bins...)
+	sort.Slice(h.bins, func(i, j int) bool { return h.bins[i].Mean < h.bins[j].Mean })
+	for len(h.bins) > h.maxBins {
+		h.mergeClosest()
+	}
+}
+
+// TotalCount returns the total number of points inserted into the histogram.
+func (h *Histogram) TotalCount() float64 {
+	var total float64
+	for _, b := range h.bins {
+		total += float64(b.Count)
+	}
+	return total
+}
+
+// Sum estimates the number of inserted points that are <= b, using the
+// trapezoidal interpolation between the two bins straddling b from the
+// Ben-Haim & Tom-Tov paper.
+func (h *Histogram) Sum(b float64) float64 {
+	if len(h.bins) == 0 {
+		return 0
+	}
+	if b < h.bins[0].Mean {
+		return 0
+	}
+	if b >= h.bins[len(h.bins)-1].Mean {
+		return h.TotalCount()
+	}
+
+	i := sort.Search(len(h.bins), func(i int) bool { return h.bins[i].Mean > b }) - 1
+	bi, bi1 := h.bins[i], h.bins[i+1]
+
+	mb := float64(bi.Count) + (float64(bi1.Count)-float64(bi.Count))/(bi1.Mean-bi.Mean)*(b-bi.Mean)
+	s := (float64(bi.Count) + mb) / 2 * (b - bi.Mean) / (bi1.Mean - bi.Mean)
+
+	var prior float64
+	for j := 0; j < i; j++ {
+		prior += float64(h.bins[j].Count)
+	}
+	return prior + float64(bi.Count)/2 + s
+}
+
+// Uniform returns numBoundaries-1 split points that divide the histogram
+// into numBoundaries buckets of approximately equal mass, found by
+// inverting Sum via binary search.
+func (h *Histogram) Uniform(numBoundaries int) []float64 {
+	if numBoundaries < 1 || len(h.bins) == 0 {
+		return []float64{}
+	}
+
+	total := h.TotalCount()
+	boundaries := make([]float64, 0, numBoundaries-1)
+	for k := 1; k < numBoundaries; k++ {
+		target := total * float64(k) / float64(numBoundaries)
+		boundaries = append(boundaries, h.invertSum(target))
+	}
+	return boundaries
+}
+
+// invertSum binary searches for the value v such that Sum(v) == target.
+func (h *Histogram) invertSum(target float64) float64 {
+	lo, hi := h.bins[0].Mean, h.bins[len(h.bins)-1].Mean
+	for i := 0; i < 50; i++ {
+		mid := (lo + hi) / 2
+		if h.Sum(mid) < target {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// Mean returns the mean of all inserted points.
+func (h *Histogram) Mean() float64 {
+	var sum float64
+	var count int
+	for _, b := range h.bins {
+		sum += b.Sum
+		count += b.Count
+	}
+	if count == 0 {
+		return 0
+	}
+	return sum / float64(count)
+}
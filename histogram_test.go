@@ -0,0 +1,57 @@
+package quantiles
+
+import "testing"
+
+func TestHistogramMeanAndSum(t *testing.T) {
+	h := NewHistogram(10)
+	for i := 1; i <= 100; i++ {
+		h.Insert(float64(i))
+	}
+
+	if got, want := h.Mean(), 50.5; got != want {
+		t.Fatalf("Mean() = %v, want %v", got, want)
+	}
+	if got := h.Sum(50); got < 40 || got > 60 {
+		t.Fatalf("Sum(50) = %v, want within [40,60]", got)
+	}
+	if got := h.Sum(0); got != 0 {
+		t.Fatalf("Sum(0) = %v, want 0", got)
+	}
+	if got, want := h.Sum(1000), h.TotalCount(); got != want {
+		t.Fatalf("Sum(1000) = %v, want %v", got, want)
+	}
+}
+
+func TestHistogramMerge(t *testing.T) {
+	h1 := NewHistogram(10)
+	h2 := NewHistogram(10)
+	for i := 1; i <= 50; i++ {
+		h1.Insert(float64(i))
+	}
+	for i := 51; i <= 100; i++ {
+		h2.Insert(float64(i))
+	}
+	h1.Merge(h2)
+
+	if got, want := h1.TotalCount(), 100.0; got != want {
+		t.Fatalf("TotalCount() = %v, want %v", got, want)
+	}
+}
+
+func TestHistogramUniform(t *testing.T) {
+	h := NewHistogram(20)
+	for i := 1; i <= 100; i++ {
+		h.Insert(float64(i))
+	}
+	boundaries := h.Uniform(4)
+	if len(boundaries) != 3 {
+		t.Fatalf("len(Uniform(4)) = %v, want 3", len(boundaries))
+	}
+}
+
+func TestNewHistogramGuardsMaxBins(t *testing.T) {
+	h := NewHistogram(0)
+	h.Insert(1)
+	h.Insert(2)
+	h.Insert(3)
+}
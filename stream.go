@@ -0,0 +1,72 @@
+package quantiles
+
+import "sort"
+
+// bufEntry is a single buffered observation, not yet folded into a Summary.
+type bufEntry struct {
+	value  float64
+	weight float64
+}
+
+func maxInt64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func maxFloat64(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// Stream is the single-writer entry point for building up a Summary:
+// Observe buffers raw values and transparently folds them into the
+// underlying summary, compressed to sizeHint entries at epsilon minEps,
+// once the buffer fills.
+type Stream struct {
+	sizeHint int64
+	minEps   float64
+	buffered []bufEntry
+	summary  *Summary
+}
+
+// NewStream returns an empty Stream compressing to sizeHint entries at
+// epsilon minEps.
+func NewStream(sizeHint int64, minEps float64) *Stream {
+	return &Stream{
+		sizeHint: sizeHint,
+		minEps:   minEps,
+		summary:  newSummary(),
+	}
+}
+
+// Observe records a new value, flushing the buffer into the summary once it
+// reaches sizeHint entries.
+func (s *Stream) Observe(v float64) {
+	s.buffered = append(s.buffered, bufEntry{value: v, weight: 1})
+	if int64(len(s.buffered)) >= s.sizeHint {
+		s.flush()
+	}
+}
+
+func (s *Stream) flush() {
+	if len(s.buffered) == 0 {
+		return
+	}
+	sort.Slice(s.buffered, func(i, j int) bool { return s.buffered[i].value < s.buffered[j].value })
+	incoming := &Summary{}
+	incoming.buildFromBufferEntries(s.buffered)
+	s.buffered = s.buffered[:0]
+	s.summary.Merge(incoming)
+	s.summary.compress(s.sizeHint, s.minEps)
+}
+
+// Summary flushes any buffered observations and returns the underlying
+// Summary.
+func (s *Stream) Summary() *Summary {
+	s.flush()
+	return s.summary
+}
@@ -1,5 +1,7 @@
 package quantiles
 
+import "sort"
+
 // SumEntry represents a summary entry
 type SumEntry struct {
 	value   float64
@@ -171,8 +173,21 @@ func (sum *Summary) compress(sizeHint int64, minEps float64) {
 		return
 	}
 
-	// First compute the max error bound delta resulting from this compression.
+	// First compute the max error bound delta resulting from this compression,
+	// then fall back to the generic bounded compression with a flat bound.
 	epsDelta := sum.TotalWeight() * maxFloat64(1/float64(sizeHint), minEps)
+	sum.compressWithBound(sizeHint, func(rank, totalWeight float64) float64 {
+		return epsDelta
+	})
+}
+
+// compressWithBound is the generic form of compress: instead of a single flat
+// epsDelta it accepts a bound(rank, totalWeight) func giving the maximum
+// tolerated rank gap around a given rank, letting callers bias compression
+// towards tighter tolerances at the ranks they care about (see
+// TargetedSummary). The sweep itself is unchanged from compress.
+func (sum *Summary) compressWithBound(sizeHint int64, bound func(rank, totalWeight float64) float64) {
+	totalWeight := sum.TotalWeight()
 
 	// Compress elements ensuring approximation bounds and elements diversity are both maintained.
 	var (
@@ -186,7 +201,7 @@ func (sum *Summary) compress(sizeHint int64, minEps float64) {
 	for ri := 0; ri+1 != len(sum.entries); {
 		ni := ri + 1
 		for ni != len(sum.entries) && addAccumulator < addStep &&
-			sum.entries[ni].prevMaxRank()-sum.entries[ri].nextMinRank() <= epsDelta {
+			sum.entries[ni].prevMaxRank()-sum.entries[ri].nextMinRank() <= bound(sum.entries[ri].nextMinRank(), totalWeight) {
 			addAccumulator += sizeHint
 			ni++
 		}
@@ -268,6 +283,59 @@ func (sum *Summary) GenerateQuantiles(numQuantiles int64) []float64 {
 	return output
 }
 
+// Rank returns the minimum and maximum rank of v within the summary,
+// interpolating from the neighboring SumEntrys when v lies strictly between
+// two entries. A v below the first entry gives (0, 0), and a v at or above
+// the last entry gives that entry's own minRank and maxRank.
+func (sum *Summary) Rank(v float64) (minRank, maxRank float64) {
+	if len(sum.entries) == 0 {
+		return 0, 0
+	}
+	if v < sum.entries[0].value {
+		return 0, 0
+	}
+	if last := sum.entries[len(sum.entries)-1]; v >= last.value {
+		return last.minRank, last.maxRank
+	}
+
+	idx := sort.Search(len(sum.entries), func(i int) bool { return sum.entries[i].value >= v })
+	entry := sum.entries[idx]
+	if entry.value == v {
+		return entry.minRank, entry.maxRank
+	}
+	prev := sum.entries[idx-1]
+	return prev.nextMinRank(), entry.prevMaxRank()
+}
+
+// CDF returns the midpoint of v's rank range from Rank as a fraction of the
+// total weight, (minRank+maxRank)/(2*TotalWeight). For v at or above the
+// last entry this is that entry's midpoint, so it is below 1.
+func (sum *Summary) CDF(v float64) float64 {
+	if totalWeight := sum.TotalWeight(); totalWeight != 0 {
+		minRank, maxRank := sum.Rank(v)
+		return (minRank + maxRank) / (2 * totalWeight)
+	}
+	return 0
+}
+
+// Quantile returns the value at quantile phi, the single-point equivalent of
+// GenerateQuantiles without allocating a slice.
+func (sum *Summary) Quantile(phi float64) float64 {
+	if len(sum.entries) == 0 {
+		return 0
+	}
+	d2 := 2 * phi * sum.entries[len(sum.entries)-1].maxRank
+	nextIdx := 1
+	for nextIdx < len(sum.entries) && d2 >= sum.entries[nextIdx].minRank+sum.entries[nextIdx].maxRank {
+		nextIdx++
+	}
+	curIdx := nextIdx - 1
+	if nextIdx == len(sum.entries) || d2 < sum.entries[curIdx].nextMinRank()+sum.entries[nextIdx].prevMaxRank() {
+		return sum.entries[curIdx].value
+	}
+	return sum.entries[nextIdx].value
+}
+
 // ApproximationError ...
 func (sum *Summary) ApproximationError() float64 {
 	if len(sum.entries) == 0 {
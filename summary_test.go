@@ -0,0 +1,47 @@
+package quantiles
+
+import "testing"
+
+func buildSummary(values []float64) *Summary {
+	entries := make([]bufEntry, len(values))
+	for i, v := range values {
+		entries[i] = bufEntry{value: v, weight: 1}
+	}
+	sum := &Summary{}
+	sum.buildFromBufferEntries(entries)
+	return sum
+}
+
+func TestSummaryRankEdgeCases(t *testing.T) {
+	sum := buildSummary([]float64{1, 2, 3, 4, 5})
+
+	if minRank, maxRank := sum.Rank(0); minRank != 0 || maxRank != 0 {
+		t.Fatalf("Rank(0) = (%v, %v), want (0, 0)", minRank, maxRank)
+	}
+	if minRank, maxRank := sum.Rank(10); minRank != 4 || maxRank != 5 {
+		t.Fatalf("Rank(10) = (%v, %v), want (4, 5)", minRank, maxRank)
+	}
+	if minRank, maxRank := sum.Rank(3); minRank != 2 || maxRank != 3 {
+		t.Fatalf("Rank(3) = (%v, %v), want (2, 3)", minRank, maxRank)
+	}
+}
+
+func TestSummaryCDF(t *testing.T) {
+	sum := buildSummary([]float64{1, 2, 3, 4, 5})
+	if got := sum.CDF(10); got != 0.9 {
+		t.Fatalf("CDF(10) = %v, want 0.9", got)
+	}
+	if got := sum.CDF(0); got != 0 {
+		t.Fatalf("CDF(0) = %v, want 0", got)
+	}
+}
+
+func TestSummaryQuantile(t *testing.T) {
+	sum := buildSummary([]float64{1, 2, 3, 4, 5})
+	if got := sum.Quantile(1); got != 5 {
+		t.Fatalf("Quantile(1) = %v, want 5", got)
+	}
+	if got := sum.Quantile(0); got != 1 {
+		t.Fatalf("Quantile(0) = %v, want 1", got)
+	}
+}
@@ -0,0 +1,100 @@
+package quantiles
+
+import (
+	"math"
+	"sort"
+)
+
+// Target is a single (quantile, epsilon) pair used to bias a TargetedSummary
+// towards tighter error tolerances around the quantiles the caller actually
+// queries, rather than spending the whole compression budget uniformly.
+type Target struct {
+	Quantile float64
+	Epsilon  float64
+}
+
+// TargetedSummary is a Summary compressed against a set of Targets following
+// Cormode, Korn, Muthukrishnan and Srivastava's "Effective Computation of
+// Biased Quantiles over Data Streams". Each entry's allowed rank error is the
+// tightest bound among all targets, so tail targets (e.g. {0.99, 0.001}) get
+// much better accuracy than a flat-epsilon Summary at the same memory budget.
+type TargetedSummary struct {
+	Summary
+	targets  []Target
+	sizeHint int64
+	buffered []bufEntry
+}
+
+// NewTargetedSummary returns an empty TargetedSummary biased towards
+// targets, compressing to sizeHint entries as values are Inserted.
+func NewTargetedSummary(targets []Target, sizeHint int64) *TargetedSummary {
+	return &TargetedSummary{
+		Summary:  Summary{entries: make([]SumEntry, 0)},
+		targets:  targets,
+		sizeHint: sizeHint,
+	}
+}
+
+// Insert records v, flushing into the summary and compressing against the
+// configured Targets once the buffer reaches sizeHint entries.
+func (ts *TargetedSummary) Insert(v float64) {
+	ts.buffered = append(ts.buffered, bufEntry{value: v, weight: 1})
+	if int64(len(ts.buffered)) >= ts.sizeHint {
+		ts.flush()
+	}
+}
+
+func (ts *TargetedSummary) flush() {
+	if len(ts.buffered) == 0 {
+		return
+	}
+	sort.Slice(ts.buffered, func(i, j int) bool { return ts.buffered[i].value < ts.buffered[j].value })
+	incoming := &Summary{}
+	incoming.buildFromBufferEntries(ts.buffered)
+	ts.buffered = ts.buffered[:0]
+	ts.Summary.Merge(incoming)
+	ts.Compress(ts.sizeHint)
+}
+
+// bound returns the tightest rank-gap tolerance any target allows at rank.
+func (ts *TargetedSummary) bound(rank, totalWeight float64) float64 {
+	eps := math.MaxFloat64
+	for _, t := range ts.targets {
+		var b float64
+		if rank >= t.Quantile*totalWeight {
+			b = 2 * t.Epsilon * rank / t.Quantile
+		} else {
+			b = 2 * t.Epsilon * (totalWeight - rank) / (1 - t.Quantile)
+		}
+		if b < eps {
+			eps = b
+		}
+	}
+	return eps
+}
+
+// Compress compresses the summary down towards sizeHint entries, honouring
+// the per-target rank tolerances instead of a single flat epsilon.
+func (ts *TargetedSummary) Compress(sizeHint int64) {
+	sizeHint = maxInt64(sizeHint, 2)
+	if int64(len(ts.entries)) <= sizeHint {
+		return
+	}
+	ts.compressWithBound(sizeHint, ts.bound)
+}
+
+// Query returns the value at quantile phi, walking entries left to right
+// until the entry straddling the requested rank is found.
+func (ts *TargetedSummary) Query(phi float64) float64 {
+	ts.flush()
+	if len(ts.entries) == 0 {
+		return 0
+	}
+	target := 2 * phi * ts.TotalWeight()
+	for _, entry := range ts.entries {
+		if entry.minRank+entry.maxRank >= target {
+			return entry.value
+		}
+	}
+	return ts.entries[len(ts.entries)-1].value
+}
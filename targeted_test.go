@@ -0,0 +1,52 @@
+package quantiles
+
+import "testing"
+
+func TestTargetedSummaryQuery(t *testing.T) {
+	ts := NewTargetedSummary([]Target{
+		{Quantile: 0.5, Epsilon: 0.05},
+		{Quantile: 0.99, Epsilon: 0.001},
+	}, 50)
+
+	entries := make([]bufEntry, 0, 1000)
+	for i := 1; i <= 1000; i++ {
+		entries = append(entries, bufEntry{value: float64(i), weight: 1})
+	}
+	ts.buildFromBufferEntries(entries)
+	ts.Compress(50)
+
+	if got := ts.Query(0.5); got < 450 || got > 550 {
+		t.Fatalf("Query(0.5) = %v, want within [450,550]", got)
+	}
+	if got := ts.Query(0.99); got < 970 || got > 1000 {
+		t.Fatalf("Query(0.99) = %v, want within [970,1000]", got)
+	}
+}
+
+func TestTargetedSummaryCompressBelowSizeHintIsNoop(t *testing.T) {
+	ts := NewTargetedSummary([]Target{{Quantile: 0.5, Epsilon: 0.05}}, 10)
+	entries := []bufEntry{{value: 1, weight: 1}, {value: 2, weight: 1}}
+	ts.buildFromBufferEntries(entries)
+	ts.Compress(10)
+	if got := ts.Size(); got != 2 {
+		t.Fatalf("Size() = %v, want 2", got)
+	}
+}
+
+func TestTargetedSummaryInsert(t *testing.T) {
+	ts := NewTargetedSummary([]Target{
+		{Quantile: 0.5, Epsilon: 0.05},
+		{Quantile: 0.99, Epsilon: 0.001},
+	}, 50)
+
+	for i := 1; i <= 1000; i++ {
+		ts.Insert(float64(i))
+	}
+
+	if got := ts.Query(0.5); got < 450 || got > 560 {
+		t.Fatalf("Query(0.5) = %v, want within [450,560]", got)
+	}
+	if got := ts.Query(0.99); got < 970 || got > 1000 {
+		t.Fatalf("Query(0.99) = %v, want within [970,1000]", got)
+	}
+}